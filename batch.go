@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"sync"
+)
+
+// RunBatch fans Viewer() calls for each host out across a worker pool,
+// caching each generated JNLP and optionally handing it straight to
+// javaws.
+func RunBatch(hosts []HostEntry, workers int, passwordCmd string, launch bool) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan HostEntry)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for h := range jobs {
+				if err := runBatchHost(h, passwordCmd, launch); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("%s: %w", h.Host, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, h := range hosts {
+		jobs <- h
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		for _, err := range errs {
+			log.Print(err)
+		}
+		return fmt.Errorf("%d of %d hosts failed", len(errs), len(hosts))
+	}
+
+	return nil
+}
+
+// runBatchHost resolves credentials, generates and caches the JNLP for
+// a single inventory entry, and optionally launches it.
+func runBatchHost(h HostEntry, passwordCmd string, launch bool) error {
+	username, password, err := ResolveCredentials(h, passwordCmd)
+	if err != nil {
+		return err
+	}
+
+	d := &DRAC{Host: h.Host, Username: username, Password: password, Version: h.Version}
+
+	jnlp, err := d.Viewer()
+	if err != nil {
+		return err
+	}
+
+	path, err := cacheJNLP(h.Host, jnlp)
+	if err != nil {
+		return err
+	}
+	log.Printf("%s: cached JNLP at %s", h.Host, path)
+
+	if launch {
+		if err := exec.Command("javaws", path).Start(); err != nil {
+			return fmt.Errorf("launching javaws: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser spawns the platform's default browser on the given URL,
+// used by -mode html5 to hand the iDRAC9 console URL straight to the
+// user instead of printing it.
+func openBrowser(url string) error {
+	var cmd string
+	var args []string
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = "open"
+	case "windows":
+		cmd = "rundll32"
+		args = []string{"url.dll,FileProtocolHandler"}
+	default:
+		cmd = "xdg-open"
+	}
+
+	args = append(args, url)
+
+	if err := exec.Command(cmd, args...).Start(); err != nil {
+		return fmt.Errorf("unable to open browser: %w", err)
+	}
+
+	return nil
+}
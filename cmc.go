@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CMC contains all of the information required to connect to a
+// Dell M1000e Chassis Management Controller (CMC) and enumerate
+// the KVM launch arguments for an individual blade slot.
+type CMC struct {
+	Host        string
+	Username    string
+	Password    string
+	Model       string
+	session     string
+	mu          sync.Mutex
+	inflight    chan struct{}
+	stopRefresh chan struct{}
+}
+
+var sessionCookieRe = regexp.MustCompile(`'SESSION_COOKIE'\s*:\s*'([^']*)'`)
+var systemModelRe = regexp.MustCompile(`'SYSTEM_MODEL'\s*:\s*'([^']*)'`)
+var argumentRe = regexp.MustCompile(`<argument>([^<]*)</argument>`)
+
+// newCMC returns a CMC ready to be logged into. The inflight channel is
+// buffered to size 1 so only a single request is ever in flight against
+// the chassis at a time, since the CMC enforces a small concurrent
+// session cap.
+func newCMC(host, username, password string) *CMC {
+	return &CMC{
+		Host:     host,
+		Username: username,
+		Password: password,
+		inflight: make(chan struct{}, 1),
+	}
+}
+
+// Login performs a form-based login to the CMC's /cgi-bin/login page and
+// scrapes the session cookie and system model out of the returned
+// HTML/JS. The session is refreshed automatically in the background
+// until Close is called.
+func (c *CMC) Login() error {
+	// Acquired before c.mu, in the same order as GetKVMData, so the
+	// background refresh never runs alongside a KVM page request.
+	c.inflight <- struct{}{}
+	defer func() { <-c.inflight }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := fmt.Sprintf("user=%s&password=%s", c.Username, c.Password)
+	response, err := httpClient.Post("https://"+c.Host+"/cgi-bin/login", "application/x-www-form-urlencoded", strings.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	buff, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	body := string(buff)
+
+	session := sessionCookieRe.FindStringSubmatch(body)
+	if session == nil {
+		return errors.New("unable to find SESSION_COOKIE in CMC login response")
+	}
+	c.session = session[1]
+
+	if model := systemModelRe.FindStringSubmatch(body); model != nil {
+		c.Model = model[1]
+	}
+
+	if c.stopRefresh == nil {
+		stop := make(chan struct{})
+		c.stopRefresh = stop
+		go c.refreshLoop(stop)
+	}
+
+	return nil
+}
+
+// refreshLoop re-logs in periodically so long-running servers don't lose
+// the CMC session out from under in-flight requests. stop is passed in
+// rather than read from c.stopRefresh on every iteration, since that
+// field is reassigned under c.mu by Login and Close and reading it
+// unlocked here would race with those writes.
+func (c *CMC) refreshLoop(stop chan struct{}) {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Login(); err != nil {
+				log.Printf("CMC session refresh for %s failed: %s", c.Host, err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Close stops the background session-refresh goroutine.
+func (c *CMC) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopRefresh != nil {
+		close(c.stopRefresh)
+		c.stopRefresh = nil
+	}
+}
+
+// GetKVMData fetches the KVM launch page for the given blade slot
+// (1-16) and returns the ordered list of <argument> values that make up
+// the JNLP application arguments.
+func (c *CMC) GetKVMData(slot int) ([]string, error) {
+	if slot < 1 || slot > 16 {
+		return nil, fmt.Errorf("blade slot %d out of range, must be 1-16", slot)
+	}
+
+	// Only one request against the CMC at a time: it only tolerates a
+	// handful of concurrent sessions before it starts rejecting logins.
+	c.inflight <- struct{}{}
+	defer func() { <-c.inflight }()
+
+	c.mu.Lock()
+	session := c.session
+	c.mu.Unlock()
+	if session == "" {
+		return nil, errors.New("not logged in to CMC")
+	}
+
+	url := fmt.Sprintf("https://%s/cgi-bin/webcgi/kvm?blade=%d", c.Host, slot)
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Cookie", "sid="+session)
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	buff, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := argumentRe.FindAllStringSubmatch(string(buff), -1)
+	args := make([]string, 0, len(matches))
+	for _, m := range matches {
+		args = append(args, m[1])
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no KVM arguments found for blade %d", slot)
+	}
+
+	return args, nil
+}
+
+// LaunchViewer builds the JNLP body used to open a viewer for the given
+// blade slot on this chassis.
+func (c *CMC) LaunchViewer(slot int) (string, error) {
+	args, err := c.GetKVMData(slot)
+	if err != nil {
+		return "", err
+	}
+
+	var jnlp strings.Builder
+	jnlp.WriteString("<jnlp>\n  <application-desc>\n")
+	for _, a := range args {
+		jnlp.WriteString("    <argument>" + a + "</argument>\n")
+	}
+	jnlp.WriteString("  </application-desc>\n</jnlp>\n")
+
+	return jnlp.String(), nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSessionCookieRe(t *testing.T) {
+	body := `var config = { 'SESSION_COOKIE' : 'abc123DEF', 'SYSTEM_MODEL' : 'PowerEdge M620' };`
+
+	m := sessionCookieRe.FindStringSubmatch(body)
+	if m == nil || m[1] != "abc123DEF" {
+		t.Fatalf("sessionCookieRe match = %v, want abc123DEF", m)
+	}
+
+	m = systemModelRe.FindStringSubmatch(body)
+	if m == nil || m[1] != "PowerEdge M620" {
+		t.Fatalf("systemModelRe match = %v, want PowerEdge M620", m)
+	}
+}
+
+func TestArgumentRe(t *testing.T) {
+	body := `<argument>10.0.0.5</argument><argument>5900</argument>`
+
+	matches := argumentRe.FindAllStringSubmatch(body, -1)
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+	if matches[0][1] != "10.0.0.5" || matches[1][1] != "5900" {
+		t.Fatalf("got %v, want [10.0.0.5 5900]", matches)
+	}
+}
+
+func TestGetKVMDataSlotRange(t *testing.T) {
+	c := newCMC("unused", "user", "pass")
+
+	if _, err := c.GetKVMData(0); err == nil {
+		t.Error("expected an error for slot 0, got nil")
+	}
+	if _, err := c.GetKVMData(17); err == nil {
+		t.Error("expected an error for slot 17, got nil")
+	}
+}
+
+// newTestCMC spins up an httptest.Server standing in for a CMC and
+// returns a *CMC pointed at it, stripping the scheme from its address
+// since CMC always dials https://<Host>.
+func newTestCMC(t *testing.T, mux *http.ServeMux) *CMC {
+	t.Helper()
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	c := newCMC(host, "user", "pass")
+	t.Cleanup(c.Close)
+
+	return c
+}
+
+func TestCMCLoginAndGetKVMData(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cgi-bin/login", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `var config = { 'SESSION_COOKIE' : 'sess-xyz', 'SYSTEM_MODEL' : 'PowerEdge M1000e' };`)
+	})
+	mux.HandleFunc("/cgi-bin/webcgi/kvm", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("blade") != "6" {
+			http.Error(w, "wrong blade", http.StatusBadRequest)
+			return
+		}
+		if !strings.Contains(r.Header.Get("Cookie"), "sess-xyz") {
+			http.Error(w, "missing session cookie", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `<argument>10.0.0.5</argument><argument>5900</argument><argument>3668</argument>`)
+	})
+
+	c := newTestCMC(t, mux)
+
+	if err := c.Login(); err != nil {
+		t.Fatalf("Login() returned error: %s", err)
+	}
+	if c.Model != "PowerEdge M1000e" {
+		t.Errorf("Model = %q, want PowerEdge M1000e", c.Model)
+	}
+
+	args, err := c.GetKVMData(6)
+	if err != nil {
+		t.Fatalf("GetKVMData() returned error: %s", err)
+	}
+
+	want := []string{"10.0.0.5", "5900", "3668"}
+	if len(args) != len(want) {
+		t.Fatalf("got %v, want %v", args, want)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("args[%d] = %q, want %q", i, args[i], want[i])
+		}
+	}
+}
+
+func TestCMCLoginMissingSessionCookie(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cgi-bin/login", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `not a valid login response`)
+	})
+
+	c := newTestCMC(t, mux)
+
+	if err := c.Login(); err == nil {
+		t.Fatal("expected an error when SESSION_COOKIE is missing, got nil")
+	}
+}
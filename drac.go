@@ -22,6 +22,11 @@ type DRAC struct {
 	Password   string
 	Version    int
 	SessionKey string
+
+	// sid is the SuperMicro login cookie, scoped per-DRAC rather than
+	// a package global so that concurrent batch-mode requests against
+	// different hosts can't cross-contaminate each other's sessions.
+	sid string
 }
 
 // Templates is a map of each viewer.jnlp template for
@@ -41,10 +46,6 @@ func init() {
         httpClient = createHTTPClient()
 }
 
-// there must be a better way to store this for re-use
-// look at setting the cookie properly?
-var SID = ""
-var sessionkey = ""
 
 // createHTTPClient for connection re-use
 func createHTTPClient() *http.Client {
@@ -88,6 +89,12 @@ func (d *DRAC) GetVersion() int {
 
 	version := -1
 
+	// Check for iDRAC9: Dell dropped the Java viewer in favor of the
+	// HTML5 eHTML5 console, so the jar-probes below always miss it.
+	if d.isIDRAC9() {
+		return 9
+	}
+
 	// Check for iDRAC7 specific libs
 	if response, err := httpClient.Head("https://" + d.Host + "/software/avctKVMIOMac64.jar"); err == nil {
 		defer response.Body.Close()
@@ -127,7 +134,7 @@ func (d *DRAC) GetVersion() int {
 		if response.StatusCode == 200 {
 			for _, c := range response.Cookies() {
 				if "SID" == c.Name && c.Value != "" {
-					SID = "SID="+c.Value
+					d.sid = "SID="+c.Value
 					log.Printf("Setting username/password to cookie SID")
 					d.Username = c.Value
 					d.Password = c.Value
@@ -147,7 +154,7 @@ func (d *DRAC) get_jnlp() (string, error){
 	url := "https://"+d.Host+"/cgi/url_redirect.cgi?url_name=ikvm&url_type=jwsk"
 	request, err := http.NewRequest("GET", url, nil)
 	if err == nil {
-		request.Header.Add("Cookie", SID)
+		request.Header.Add("Cookie", d.sid)
 		// Seems to avoid 500 errors on some SuperMicro interfaces
 		request.Header.Add("Referer", "127.0.0.1")
 	}
@@ -180,8 +187,12 @@ func (d *DRAC) Viewer() (string, error) {
 	if version < 0 {
 		return "", errors.New("unable to detect DRAC version")
 	}
+	d.Version = version
 
-	if version == 6 || version == 7 {
+	if version == 9 {
+		log.Printf("Found iDRAC9")
+		return d.htmlFiveURL()
+	} else if version == 6 || version == 7 {
 		log.Printf("Found iDRAC version %d", version)
 
 		if _, ok := Templates[version]; !ok {
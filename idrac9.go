@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+)
+
+// isIDRAC9 distinguishes iDRAC9 from iDRAC7/8 by probing for resources
+// that only exist on the newer HTML5-only firmware: the restgui SPA and
+// the Redfish Manager resource.
+func (d *DRAC) isIDRAC9() bool {
+	if response, err := httpClient.Get("https://" + d.Host + "/restgui/"); err == nil {
+		defer response.Body.Close()
+		if response.StatusCode == 200 {
+			return true
+		}
+	}
+
+	if response, err := httpClient.Get("https://" + d.Host + "/redfish/v1/Managers/iDRAC.Embedded.1"); err == nil {
+		defer response.Body.Close()
+		if response.StatusCode == 200 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bmcSessionResponse is the subset of the /sysmgmt/2015/bmc/session
+// response we need to build an HTML5 console URL.
+type bmcSessionResponse struct {
+	AppwebSessionID string `json:"_appwebSessionId_"`
+	XSRFToken       string `json:"XSRF-TOKEN"`
+}
+
+// htmlFiveURL logs in to the iDRAC9 session endpoint and returns a
+// https://<host>/console?... URL that opens the HTML5 KVM viewer with
+// the temporary credentials embedded.
+func (d *DRAC) htmlFiveURL() (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"user":     d.Username,
+		"password": d.Password,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	response, err := httpClient.Post("https://"+d.Host+"/sysmgmt/2015/bmc/session", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return "", fmt.Errorf("iDRAC9 login failed with status %d", response.StatusCode)
+	}
+
+	buff, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var session bmcSessionResponse
+	if err := json.Unmarshal(buff, &session); err != nil {
+		return "", err
+	}
+	if session.AppwebSessionID == "" {
+		return "", errors.New("no _appwebSessionId_ in iDRAC9 session response")
+	}
+
+	query := url.Values{
+		"tempUsername":    {d.Username},
+		"tempPassword":    {d.Password},
+		"appwebSessionId": {session.AppwebSessionID},
+	}
+
+	return fmt.Sprintf("https://%s/console?%s", d.Host, query.Encode()), nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HostEntry is a single target in an -inventory file. Username and
+// Password are optional: when left blank they're resolved at launch
+// time from the environment, netrc, or -password-cmd.
+type HostEntry struct {
+	Host     string   `yaml:"host" json:"host"`
+	Username string   `yaml:"username" json:"username"`
+	Password string   `yaml:"password" json:"password"`
+	Version  int      `yaml:"version" json:"version"`
+	Alias    string   `yaml:"alias" json:"alias"`
+	Tags     []string `yaml:"tags" json:"tags"`
+}
+
+// LoadInventory reads a list of HostEntry values from a YAML or JSON
+// file, selected by its extension.
+func LoadInventory(path string) ([]HostEntry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []HostEntry
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, &hosts)
+	} else {
+		err = yaml.Unmarshal(data, &hosts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing inventory %s: %w", path, err)
+	}
+
+	for i := range hosts {
+		if hosts[i].Version == 0 {
+			hosts[i].Version = -1
+		}
+	}
+
+	return hosts, nil
+}
+
+// SelectHosts returns the entries matching alias (exact match, if set)
+// or tag (membership, if set). An empty alias and tag select every
+// host in the inventory.
+func SelectHosts(hosts []HostEntry, alias, tag string) []HostEntry {
+	if alias == "" && tag == "" {
+		return hosts
+	}
+
+	var selected []HostEntry
+	for _, h := range hosts {
+		if alias != "" && h.Alias == alias {
+			selected = append(selected, h)
+			continue
+		}
+		if tag != "" {
+			for _, t := range h.Tags {
+				if t == tag {
+					selected = append(selected, h)
+					break
+				}
+			}
+		}
+	}
+
+	return selected
+}
+
+// ResolveCredentials fills in a HostEntry's username/password from,
+// in order: the entry itself, the DRACKVM_USERNAME/DRACKVM_PASSWORD
+// environment variables, ~/.netrc, and finally passwordCmd (a shell
+// command, e.g. `pass show hosts/idrac1`, whose trimmed stdout becomes
+// the password).
+func ResolveCredentials(h HostEntry, passwordCmd string) (username, password string, err error) {
+	username = h.Username
+	password = h.Password
+
+	if username == "" {
+		username = os.Getenv("DRACKVM_USERNAME")
+	}
+	if password == "" {
+		password = os.Getenv("DRACKVM_PASSWORD")
+	}
+
+	if username == "" || password == "" {
+		if netrcUser, netrcPass, ok := lookupNetrc(h.Host); ok {
+			if username == "" {
+				username = netrcUser
+			}
+			if password == "" {
+				password = netrcPass
+			}
+		}
+	}
+
+	if password == "" && passwordCmd != "" {
+		out, cmdErr := exec.Command("sh", "-c", passwordCmd).Output()
+		if cmdErr != nil {
+			return "", "", fmt.Errorf("password-cmd for %s: %w", h.Host, cmdErr)
+		}
+		password = strings.TrimSpace(string(out))
+	}
+
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("no credentials available for %s", h.Host)
+	}
+
+	return username, password, nil
+}
+
+// cacheDir returns ~/.cache/drac-kvm, creating it if necessary.
+func cacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(home, ".cache", "drac-kvm")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// cacheJNLP writes the generated JNLP for host to the drac-kvm cache
+// directory and returns the path it was written to.
+func cacheJNLP(host, jnlp string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, host+".jnlp")
+	if err := ioutil.WriteFile(path, []byte(jnlp), 0600); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withEmptyHome points os.UserHomeDir() (and therefore lookupNetrc) at a
+// fresh directory with no .netrc, so tests don't depend on whatever
+// happens to be in the machine's real home directory.
+func withEmptyHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestResolveCredentialsEntryWins(t *testing.T) {
+	withEmptyHome(t)
+	t.Setenv("DRACKVM_USERNAME", "env-user")
+	t.Setenv("DRACKVM_PASSWORD", "env-pass")
+
+	h := HostEntry{Host: "drac1", Username: "entry-user", Password: "entry-pass"}
+
+	username, password, err := ResolveCredentials(h, "")
+	if err != nil {
+		t.Fatalf("ResolveCredentials returned error: %s", err)
+	}
+	if username != "entry-user" || password != "entry-pass" {
+		t.Errorf("got (%q, %q), want entry-user/entry-pass", username, password)
+	}
+}
+
+func TestResolveCredentialsEnvFallback(t *testing.T) {
+	withEmptyHome(t)
+	t.Setenv("DRACKVM_USERNAME", "env-user")
+	t.Setenv("DRACKVM_PASSWORD", "env-pass")
+
+	h := HostEntry{Host: "drac1"}
+
+	username, password, err := ResolveCredentials(h, "")
+	if err != nil {
+		t.Fatalf("ResolveCredentials returned error: %s", err)
+	}
+	if username != "env-user" || password != "env-pass" {
+		t.Errorf("got (%q, %q), want env-user/env-pass", username, password)
+	}
+}
+
+func TestResolveCredentialsNetrcFallback(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("DRACKVM_USERNAME", "")
+	t.Setenv("DRACKVM_PASSWORD", "")
+
+	netrc := "machine drac1 login netrc-user password netrc-pass\n"
+	if err := os.WriteFile(filepath.Join(home, ".netrc"), []byte(netrc), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	h := HostEntry{Host: "drac1"}
+
+	username, password, err := ResolveCredentials(h, "")
+	if err != nil {
+		t.Fatalf("ResolveCredentials returned error: %s", err)
+	}
+	if username != "netrc-user" || password != "netrc-pass" {
+		t.Errorf("got (%q, %q), want netrc-user/netrc-pass", username, password)
+	}
+}
+
+func TestResolveCredentialsPasswordCmdFallback(t *testing.T) {
+	withEmptyHome(t)
+	t.Setenv("DRACKVM_USERNAME", "")
+	t.Setenv("DRACKVM_PASSWORD", "")
+
+	h := HostEntry{Host: "drac1", Username: "entry-user"}
+
+	username, password, err := ResolveCredentials(h, "echo cmd-pass")
+	if err != nil {
+		t.Fatalf("ResolveCredentials returned error: %s", err)
+	}
+	if username != "entry-user" || password != "cmd-pass" {
+		t.Errorf("got (%q, %q), want entry-user/cmd-pass", username, password)
+	}
+}
+
+func TestResolveCredentialsNoneAvailable(t *testing.T) {
+	withEmptyHome(t)
+	t.Setenv("DRACKVM_USERNAME", "")
+	t.Setenv("DRACKVM_PASSWORD", "")
+
+	if _, _, err := ResolveCredentials(HostEntry{Host: "drac1"}, ""); err == nil {
+		t.Fatal("expected an error when no credentials are available, got nil")
+	}
+}
+
+func TestSelectHosts(t *testing.T) {
+	hosts := []HostEntry{
+		{Host: "drac1", Alias: "web1", Tags: []string{"rack-3", "web"}},
+		{Host: "drac2", Alias: "web2", Tags: []string{"rack-3", "web"}},
+		{Host: "drac3", Alias: "db1", Tags: []string{"rack-4", "db"}},
+	}
+
+	if got := SelectHosts(hosts, "", ""); len(got) != 3 {
+		t.Errorf("no filter: got %d hosts, want 3", len(got))
+	}
+
+	if got := SelectHosts(hosts, "web1", ""); len(got) != 1 || got[0].Host != "drac1" {
+		t.Errorf("alias filter: got %+v, want only drac1", got)
+	}
+
+	got := SelectHosts(hosts, "", "rack-3")
+	if len(got) != 2 {
+		t.Fatalf("tag filter: got %d hosts, want 2", len(got))
+	}
+	if got[0].Host != "drac1" || got[1].Host != "drac2" {
+		t.Errorf("tag filter: got %+v, want drac1 and drac2", got)
+	}
+}
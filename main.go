@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	host := flag.String("host", "", "DRAC host to connect to")
+	username := flag.String("username", "root", "DRAC username")
+	password := flag.String("password", "", "DRAC password")
+	version := flag.Int("version", -1, "DRAC version, auto-detected if unset")
+	mode := flag.String("mode", "jnlp", "output mode: jnlp (print the viewer output, which for iDRAC9 is the console URL), html5 (iDRAC9 only: open the console URL in a browser), or url (iDRAC9 only: print the console URL)")
+	rpcAddr := flag.String("rpc-addr", "127.0.0.1:9090", "address for the serve subcommand's JSON-RPC listener")
+	httpAddr := flag.String("http-addr", "127.0.0.1:9091", "address for the serve subcommand's HTTP listener")
+	listenAddr := flag.String("listen", "127.0.0.1:5900", "local address for the proxy subcommand's RFB listener")
+	cmcHost := flag.String("cmc", "", "CMC chassis host for the proxy subcommand, instead of -host")
+	slot := flag.Int("slot", 0, "blade slot (1-16) behind -cmc for the proxy subcommand")
+	inventory := flag.String("inventory", "", "YAML or JSON inventory file for batch mode, instead of -host")
+	alias := flag.String("alias", "", "select a single inventory host by alias")
+	tag := flag.String("tag", "", "select inventory hosts by tag")
+	workers := flag.Int("workers", 4, "concurrent workers for batch mode")
+	launch := flag.Bool("launch", false, "auto-invoke javaws on each generated JNLP in batch mode")
+	passwordCmd := flag.String("password-cmd", "", "shell command whose stdout is used as the password when not found elsewhere, e.g. 'pass show hosts/idrac1'")
+	flag.Parse()
+
+	if *inventory != "" {
+		hosts, err := LoadInventory(*inventory)
+		if err != nil {
+			log.Fatal(err)
+		}
+		hosts = SelectHosts(hosts, *alias, *tag)
+		if len(hosts) == 0 {
+			log.Fatal("no inventory hosts matched -alias/-tag")
+		}
+		if err := RunBatch(hosts, *workers, *passwordCmd, *launch); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "serve" {
+		if err := Serve(*rpcAddr, *httpAddr, *username, *password); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if flag.NArg() > 0 && flag.Arg(0) == "proxy" {
+		jnlp, requiresAVCT, err := jnlpForProxy(*cmcHost, *slot, *host, *username, *password, *version)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		args, err := parseJNLPArguments(jnlp)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		proxy := &RFBProxy{ListenAddr: *listenAddr, Args: args, Username: *username, Password: *password, RequiresAVCT: requiresAVCT}
+		log.Fatal(proxy.Start())
+	}
+
+	if isRedfishVerb(flag.Arg(0)) {
+		if *host == "" {
+			log.Fatal("redfish commands require -host")
+		}
+		d := &DRAC{Host: *host, Username: *username, Password: *password, Version: *version}
+		if err := runRedfishCommand(d.Redfish(), flag.Arg(0), flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *host == "" {
+		fmt.Fprintln(os.Stderr, "usage: drac-kvm -host <host> [-username user] [-password pass] [-version n]")
+		fmt.Fprintln(os.Stderr, "       drac-kvm serve [-rpc-addr host:port] [-http-addr host:port]")
+		fmt.Fprintln(os.Stderr, "       drac-kvm proxy [-listen addr] {-host host | -cmc host -slot n}")
+		fmt.Fprintln(os.Stderr, "       drac-kvm -host <host> {power-on|power-off|power-cycle|boot-once pxe|bios|cd|sensors|sel|inventory}")
+		fmt.Fprintln(os.Stderr, "       drac-kvm -inventory hosts.yaml [-alias name | -tag name] [-workers n] [-launch]")
+		os.Exit(1)
+	}
+
+	d := &DRAC{
+		Host:     *host,
+		Username: *username,
+		Password: *password,
+		Version:  *version,
+	}
+
+	output, err := d.Viewer()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if (*mode == "html5" || *mode == "url") && d.Version != 9 {
+		log.Fatalf("-mode %s is only valid against iDRAC9, found version %d", *mode, d.Version)
+	}
+
+	switch *mode {
+	case "jnlp", "url":
+		fmt.Println(output)
+	case "html5":
+		if err := openBrowser(output); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("unknown -mode %q, must be jnlp, html5, or url", *mode)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lookupNetrc looks up a machine entry in ~/.netrc, the traditional
+// home for credentials operators don't want living in a plaintext
+// inventory file.
+func lookupNetrc(machine string) (username, password string, ok bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", false
+	}
+
+	f, err := os.Open(filepath.Join(home, ".netrc"))
+	if err != nil {
+		return "", "", false
+	}
+	defer f.Close()
+
+	var currentMachine, login, pass string
+	matched := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		for i := 0; i+1 < len(fields); i += 2 {
+			switch fields[i] {
+			case "machine":
+				if currentMachine == machine && matched {
+					return login, pass, true
+				}
+				currentMachine = fields[i+1]
+				login, pass = "", ""
+				matched = currentMachine == machine
+			case "login":
+				login = fields[i+1]
+			case "password":
+				pass = fields[i+1]
+			}
+		}
+	}
+
+	if matched && login != "" && pass != "" {
+		return login, pass, true
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestParseJNLPArguments(t *testing.T) {
+	jnlp := `<jnlp>
+  <application-desc>
+    <argument>10.0.0.5</argument>
+    <argument>5900</argument>
+    <argument>3668</argument>
+    <argument>3669</argument>
+    <argument>abc123sessionkey</argument>
+  </application-desc>
+</jnlp>`
+
+	args, err := parseJNLPArguments(jnlp)
+	if err != nil {
+		t.Fatalf("parseJNLPArguments returned error: %s", err)
+	}
+
+	if args.Host != "10.0.0.5" {
+		t.Errorf("Host = %q, want 10.0.0.5", args.Host)
+	}
+	if args.KVMPort != 5900 {
+		t.Errorf("KVMPort = %d, want 5900", args.KVMPort)
+	}
+	if args.VideoPort1 != 3668 || args.VideoPort2 != 3669 {
+		t.Errorf("VideoPort1/2 = %d/%d, want 3668/3669", args.VideoPort1, args.VideoPort2)
+	}
+	if args.SessionKey != "abc123sessionkey" {
+		t.Errorf("SessionKey = %q, want abc123sessionkey", args.SessionKey)
+	}
+	if len(args.Raw) != 5 {
+		t.Errorf("len(Raw) = %d, want 5", len(args.Raw))
+	}
+}
+
+func TestParseJNLPArgumentsTooFew(t *testing.T) {
+	jnlp := `<jnlp><application-desc>
+    <argument>10.0.0.5</argument>
+    <argument>5900</argument>
+  </application-desc></jnlp>`
+
+	if _, err := parseJNLPArguments(jnlp); err == nil {
+		t.Fatal("expected an error for a JNLP with too few arguments, got nil")
+	}
+}
+
+func TestParseJNLPArgumentsBadPort(t *testing.T) {
+	jnlp := `<jnlp><application-desc>
+    <argument>10.0.0.5</argument>
+    <argument>not-a-port</argument>
+    <argument>3668</argument>
+    <argument>3669</argument>
+    <argument>key</argument>
+  </application-desc></jnlp>`
+
+	if _, err := parseJNLPArguments(jnlp); err == nil {
+		t.Fatal("expected an error for a non-numeric KVM port, got nil")
+	}
+}
@@ -0,0 +1,181 @@
+// Package redfish implements a small client for the DMTF Redfish API
+// exposed by iDRAC7+, iLO4+, and Supermicro X11+ BMCs, giving drac-kvm
+// a single tool for both console access and lights-out lifecycle
+// management.
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Client talks to a single BMC's Redfish service root.
+type Client struct {
+	Host       string
+	Username   string
+	Password   string
+	httpClient *http.Client
+
+	// systemPath, chassisPath, and managerPath are the vendor-specific
+	// Systems/Chassis/Managers resources, e.g.
+	// /redfish/v1/Systems/System.Embedded.1 for Dell or
+	// /redfish/v1/Systems/1 for HPE and Supermicro.
+	systemPath  string
+	chassisPath string
+	managerPath string
+}
+
+// NewClient returns a Client for the given BMC host, using httpClient
+// for every request. The caller is expected to pass in the same
+// http.Client the rest of the tool already uses (TLS verification
+// disabled, connection dial timeout set), rather than have this
+// package construct its own with different timeout behavior. The
+// system resource path is resolved lazily on first use via the service
+// root's Oem block, since that's what reliably discriminates vendor
+// and generation.
+func NewClient(host, username, password string, httpClient *http.Client) *Client {
+	return &Client{
+		Host:       host,
+		Username:   username,
+		Password:   password,
+		httpClient: httpClient,
+	}
+}
+
+// serviceRoot is the subset of the Redfish /redfish/v1/ document used
+// to discriminate vendor and firmware generation.
+type serviceRoot struct {
+	Oem struct {
+		Dell interface{} `json:"Dell"`
+		Hpe  interface{} `json:"Hpe"`
+	} `json:"Oem"`
+}
+
+// resolveSystemPath fetches the service root once and caches the
+// vendor-appropriate Systems resource path.
+func (c *Client) resolveSystemPath() (string, error) {
+	if err := c.resolveVendorPaths(); err != nil {
+		return "", err
+	}
+	return c.systemPath, nil
+}
+
+// resolveChassisPath fetches the service root once and caches the
+// vendor-appropriate Chassis resource path.
+func (c *Client) resolveChassisPath() (string, error) {
+	if err := c.resolveVendorPaths(); err != nil {
+		return "", err
+	}
+	return c.chassisPath, nil
+}
+
+// resolveManagerPath fetches the service root once and caches the
+// vendor-appropriate Managers resource path.
+func (c *Client) resolveManagerPath() (string, error) {
+	if err := c.resolveVendorPaths(); err != nil {
+		return "", err
+	}
+	return c.managerPath, nil
+}
+
+// resolveVendorPaths fetches the service root's Oem block once and
+// caches the Systems/Chassis/Managers resource paths for this vendor,
+// since that block reliably discriminates vendor and generation.
+func (c *Client) resolveVendorPaths() error {
+	if c.systemPath != "" {
+		return nil
+	}
+
+	var root serviceRoot
+	if err := c.get("/redfish/v1/", &root); err != nil {
+		return err
+	}
+
+	switch {
+	case root.Oem.Dell != nil:
+		c.systemPath = "/redfish/v1/Systems/System.Embedded.1"
+		c.chassisPath = "/redfish/v1/Chassis/System.Embedded.1"
+		c.managerPath = "/redfish/v1/Managers/iDRAC.Embedded.1"
+	case root.Oem.Hpe != nil:
+		c.systemPath = "/redfish/v1/Systems/1"
+		c.chassisPath = "/redfish/v1/Chassis/1"
+		c.managerPath = "/redfish/v1/Managers/1"
+	default:
+		// Supermicro's service root carries no distinguishing Oem
+		// block; its Systems/Chassis/Managers collections only ever
+		// have one member each.
+		c.systemPath = "/redfish/v1/Systems/1"
+		c.chassisPath = "/redfish/v1/Chassis/1"
+		c.managerPath = "/redfish/v1/Managers/1"
+	}
+
+	return nil
+}
+
+// get issues an authenticated GET for path and decodes the JSON body
+// into out.
+func (c *Client) get(path string, out interface{}) error {
+	request, err := http.NewRequest("GET", "https://"+c.Host+path, nil)
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(c.Username, c.Password)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("redfish GET %s: unexpected status %d", path, response.StatusCode)
+	}
+
+	buff, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(buff, out)
+}
+
+// patch issues an authenticated PATCH of body to path.
+func (c *Client) patch(path string, body interface{}) error {
+	return c.send("PATCH", path, body)
+}
+
+// post issues an authenticated POST of body to path.
+func (c *Client) post(path string, body interface{}) error {
+	return c.send("POST", path, body)
+}
+
+func (c *Client) send(method, path string, body interface{}) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(method, "https://"+c.Host+path, strings.NewReader(string(encoded)))
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(c.Username, c.Password)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		buff, _ := ioutil.ReadAll(response.Body)
+		return fmt.Errorf("redfish %s %s: status %d: %s", method, path, response.StatusCode, buff)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package redfish
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testHTTPClient returns an http.Client that accepts the self-signed
+// certs httptest.NewTLSServer hands out, mirroring the InsecureSkipVerify
+// behavior every real caller's shared client already has.
+func testHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+}
+
+func newTestClient(t *testing.T, serviceRootBody string) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, serviceRootBody)
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	return NewClient(host, "user", "pass", testHTTPClient())
+}
+
+func TestResolveVendorPathsDell(t *testing.T) {
+	c := newTestClient(t, `{"Oem": {"Dell": {}}}`)
+
+	systemPath, err := c.resolveSystemPath()
+	if err != nil {
+		t.Fatalf("resolveSystemPath: %s", err)
+	}
+	if systemPath != "/redfish/v1/Systems/System.Embedded.1" {
+		t.Errorf("systemPath = %q, want Dell path", systemPath)
+	}
+
+	chassisPath, _ := c.resolveChassisPath()
+	if chassisPath != "/redfish/v1/Chassis/System.Embedded.1" {
+		t.Errorf("chassisPath = %q, want Dell path", chassisPath)
+	}
+
+	managerPath, _ := c.resolveManagerPath()
+	if managerPath != "/redfish/v1/Managers/iDRAC.Embedded.1" {
+		t.Errorf("managerPath = %q, want Dell path", managerPath)
+	}
+}
+
+func TestResolveVendorPathsHPE(t *testing.T) {
+	c := newTestClient(t, `{"Oem": {"Hpe": {}}}`)
+
+	systemPath, err := c.resolveSystemPath()
+	if err != nil {
+		t.Fatalf("resolveSystemPath: %s", err)
+	}
+	if systemPath != "/redfish/v1/Systems/1" {
+		t.Errorf("systemPath = %q, want /redfish/v1/Systems/1", systemPath)
+	}
+
+	managerPath, _ := c.resolveManagerPath()
+	if managerPath != "/redfish/v1/Managers/1" {
+		t.Errorf("managerPath = %q, want /redfish/v1/Managers/1", managerPath)
+	}
+}
+
+func TestResolveVendorPathsSupermicro(t *testing.T) {
+	c := newTestClient(t, `{}`)
+
+	systemPath, err := c.resolveSystemPath()
+	if err != nil {
+		t.Fatalf("resolveSystemPath: %s", err)
+	}
+	if systemPath != "/redfish/v1/Systems/1" {
+		t.Errorf("systemPath = %q, want /redfish/v1/Systems/1", systemPath)
+	}
+
+	chassisPath, _ := c.resolveChassisPath()
+	if chassisPath != "/redfish/v1/Chassis/1" {
+		t.Errorf("chassisPath = %q, want /redfish/v1/Chassis/1", chassisPath)
+	}
+}
+
+func TestResolveVendorPathsCachesServiceRoot(t *testing.T) {
+	hits := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redfish/v1/", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprint(w, `{"Oem": {"Dell": {}}}`)
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	c := NewClient(strings.TrimPrefix(server.URL, "https://"), "user", "pass", testHTTPClient())
+
+	if _, err := c.resolveSystemPath(); err != nil {
+		t.Fatalf("resolveSystemPath: %s", err)
+	}
+	if _, err := c.resolveChassisPath(); err != nil {
+		t.Fatalf("resolveChassisPath: %s", err)
+	}
+	if _, err := c.resolveManagerPath(); err != nil {
+		t.Fatalf("resolveManagerPath: %s", err)
+	}
+
+	if hits != 1 {
+		t.Errorf("service root fetched %d times, want 1 (should be cached)", hits)
+	}
+}
@@ -0,0 +1,135 @@
+package redfish
+
+import "fmt"
+
+// Sensor is a single reading from the Chassis Thermal resource.
+type Sensor struct {
+	Name         string
+	ReadingUnits string
+	Reading      float64
+	Status       string
+}
+
+// Sensors returns the temperature and fan readings reported under the
+// chassis Thermal resource.
+func (c *Client) Sensors() ([]Sensor, error) {
+	var thermal struct {
+		Temperatures []struct {
+			Name           string                  `json:"Name"`
+			ReadingCelsius float64                 `json:"ReadingCelsius"`
+			Status         struct{ Health string } `json:"Status"`
+		} `json:"Temperatures"`
+		Fans []struct {
+			Name    string                  `json:"Name"`
+			Reading float64                 `json:"Reading"`
+			Status  struct{ Health string } `json:"Status"`
+		} `json:"Fans"`
+	}
+
+	chassisPath, err := c.resolveChassisPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.get(chassisPath+"/Thermal", &thermal); err != nil {
+		return nil, err
+	}
+
+	sensors := make([]Sensor, 0, len(thermal.Temperatures)+len(thermal.Fans))
+	for _, t := range thermal.Temperatures {
+		sensors = append(sensors, Sensor{Name: t.Name, ReadingUnits: "C", Reading: t.ReadingCelsius, Status: t.Status.Health})
+	}
+	for _, f := range thermal.Fans {
+		sensors = append(sensors, Sensor{Name: f.Name, ReadingUnits: "RPM", Reading: f.Reading, Status: f.Status.Health})
+	}
+
+	return sensors, nil
+}
+
+// SELEntry is a single System Event Log record.
+type SELEntry struct {
+	ID       string
+	Created  string
+	Severity string
+	Message  string
+}
+
+// SEL returns the BMC's System Event Log.
+func (c *Client) SEL() ([]SELEntry, error) {
+	var log struct {
+		Members []struct {
+			ID       string `json:"Id"`
+			Created  string `json:"Created"`
+			Severity string `json:"Severity"`
+			Message  string `json:"Message"`
+		} `json:"Members"`
+	}
+
+	managerPath, err := c.resolveManagerPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.get(managerPath+"/LogServices/Sel/Entries", &log); err != nil {
+		return nil, err
+	}
+
+	entries := make([]SELEntry, 0, len(log.Members))
+	for _, m := range log.Members {
+		entries = append(entries, SELEntry{ID: m.ID, Created: m.Created, Severity: m.Severity, Message: m.Message})
+	}
+
+	return entries, nil
+}
+
+// Inventory is a minimal summary of the system's identifying
+// information and populated component counts.
+type Inventory struct {
+	Manufacturer   string
+	Model          string
+	SerialNumber   string
+	BIOSVersion    string
+	ProcessorCount int
+	MemoryGiB      int
+}
+
+// Inventory returns a summary of the system's identity and installed
+// hardware.
+func (c *Client) Inventory() (*Inventory, error) {
+	systemPath, err := c.resolveSystemPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var system struct {
+		Manufacturer     string `json:"Manufacturer"`
+		Model            string `json:"Model"`
+		SerialNumber     string `json:"SerialNumber"`
+		BiosVersion      string `json:"BiosVersion"`
+		ProcessorSummary struct {
+			Count int `json:"Count"`
+		} `json:"ProcessorSummary"`
+		MemorySummary struct {
+			TotalSystemMemoryGiB int `json:"TotalSystemMemoryGiB"`
+		} `json:"MemorySummary"`
+	}
+
+	if err := c.get(systemPath, &system); err != nil {
+		return nil, err
+	}
+
+	return &Inventory{
+		Manufacturer:   system.Manufacturer,
+		Model:          system.Model,
+		SerialNumber:   system.SerialNumber,
+		BIOSVersion:    system.BiosVersion,
+		ProcessorCount: system.ProcessorSummary.Count,
+		MemoryGiB:      system.MemorySummary.TotalSystemMemoryGiB,
+	}, nil
+}
+
+// String implements fmt.Stringer for quick CLI output.
+func (i *Inventory) String() string {
+	return fmt.Sprintf("%s %s (serial %s, BIOS %s, %d CPUs, %d GiB RAM)",
+		i.Manufacturer, i.Model, i.SerialNumber, i.BIOSVersion, i.ProcessorCount, i.MemoryGiB)
+}
@@ -0,0 +1,55 @@
+package redfish
+
+// resetAction issues a ComputerSystem.Reset action with the given
+// ResetType, the Redfish-standard verb for every power state change.
+func (c *Client) resetAction(resetType string) error {
+	systemPath, err := c.resolveSystemPath()
+	if err != nil {
+		return err
+	}
+
+	return c.post(systemPath+"/Actions/ComputerSystem.Reset", map[string]string{
+		"ResetType": resetType,
+	})
+}
+
+// PowerOn turns the system on.
+func (c *Client) PowerOn() error {
+	return c.resetAction("On")
+}
+
+// PowerOff performs a graceful shutdown, falling back to the BMC's own
+// behavior if the OS doesn't respond to ACPI.
+func (c *Client) PowerOff() error {
+	return c.resetAction("GracefulShutdown")
+}
+
+// PowerCycle power-cycles the system.
+func (c *Client) PowerCycle() error {
+	return c.resetAction("PowerCycle")
+}
+
+// BootTarget is a one-time boot override target.
+type BootTarget string
+
+const (
+	BootPXE  BootTarget = "Pxe"
+	BootBIOS BootTarget = "BiosSetup"
+	BootCD   BootTarget = "Cd"
+)
+
+// BootOnce sets a one-time boot override to target, applied on the
+// system's next boot only.
+func (c *Client) BootOnce(target BootTarget) error {
+	systemPath, err := c.resolveSystemPath()
+	if err != nil {
+		return err
+	}
+
+	return c.patch(systemPath, map[string]interface{}{
+		"Boot": map[string]string{
+			"BootSourceOverrideEnabled": "Once",
+			"BootSourceOverrideTarget":  string(target),
+		},
+	})
+}
@@ -0,0 +1,14 @@
+package main
+
+import "github.com/motivator/drac-kvm/redfish"
+
+// Redfish returns a Redfish client authenticated with this DRAC's
+// credentials, for lights-out lifecycle management (power control,
+// boot override, sensors, SEL, inventory) alongside the existing
+// console-access viewer flow. It shares this tool's package-level
+// httpClient so Redfish requests get the same TLS and dial-timeout
+// behavior as every other HTTP path here, instead of hanging
+// indefinitely against an unresponsive BMC.
+func (d *DRAC) Redfish() *redfish.Client {
+	return redfish.NewClient(d.Host, d.Username, d.Password, httpClient)
+}
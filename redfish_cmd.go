@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/motivator/drac-kvm/redfish"
+)
+
+// redfishVerbs are the CLI subcommands dispatched to a redfish.Client.
+var redfishVerbs = map[string]bool{
+	"power-on":    true,
+	"power-off":   true,
+	"power-cycle": true,
+	"boot-once":   true,
+	"sensors":     true,
+	"sel":         true,
+	"inventory":   true,
+}
+
+// isRedfishVerb reports whether arg names one of the Redfish lifecycle
+// subcommands.
+func isRedfishVerb(arg string) bool {
+	return redfishVerbs[arg]
+}
+
+// runRedfishCommand dispatches a single Redfish CLI verb against c.
+func runRedfishCommand(c *redfish.Client, verb string, args []string) error {
+	switch verb {
+	case "power-on":
+		return c.PowerOn()
+	case "power-off":
+		return c.PowerOff()
+	case "power-cycle":
+		return c.PowerCycle()
+	case "boot-once":
+		if len(args) != 1 {
+			return fmt.Errorf("boot-once requires a target: pxe, bios, or cd")
+		}
+		target, err := parseBootTarget(args[0])
+		if err != nil {
+			return err
+		}
+		return c.BootOnce(target)
+	case "sensors":
+		sensors, err := c.Sensors()
+		if err != nil {
+			return err
+		}
+		for _, s := range sensors {
+			fmt.Printf("%-24s %8.2f %-4s %s\n", s.Name, s.Reading, s.ReadingUnits, s.Status)
+		}
+		return nil
+	case "sel":
+		entries, err := c.SEL()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			fmt.Printf("%s [%s] %s\n", e.Created, e.Severity, e.Message)
+		}
+		return nil
+	case "inventory":
+		inventory, err := c.Inventory()
+		if err != nil {
+			return err
+		}
+		fmt.Println(inventory)
+		return nil
+	default:
+		return fmt.Errorf("unknown redfish command %q", verb)
+	}
+}
+
+// parseBootTarget maps the CLI's short boot-once target names onto the
+// Redfish BootSourceOverrideTarget enum values.
+func parseBootTarget(name string) (redfish.BootTarget, error) {
+	switch name {
+	case "pxe":
+		return redfish.BootPXE, nil
+	case "bios":
+		return redfish.BootBIOS, nil
+	case "cd":
+		return redfish.BootCD, nil
+	default:
+		return "", fmt.Errorf("unknown boot-once target %q, must be pxe, bios, or cd", name)
+	}
+}
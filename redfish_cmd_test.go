@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/motivator/drac-kvm/redfish"
+)
+
+func TestParseBootTarget(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    redfish.BootTarget
+		wantErr bool
+	}{
+		{name: "pxe", want: redfish.BootPXE},
+		{name: "bios", want: redfish.BootBIOS},
+		{name: "cd", want: redfish.BootCD},
+		{name: "floppy", wantErr: true},
+		{name: "", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := parseBootTarget(c.name)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseBootTarget(%q) = %v, nil, want an error", c.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseBootTarget(%q) returned error: %s", c.name, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseBootTarget(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestIsRedfishVerb(t *testing.T) {
+	for _, verb := range []string{"power-on", "power-off", "power-cycle", "boot-once", "sensors", "sel", "inventory"} {
+		if !isRedfishVerb(verb) {
+			t.Errorf("isRedfishVerb(%q) = false, want true", verb)
+		}
+	}
+	if isRedfishVerb("proxy") {
+		t.Error("isRedfishVerb(\"proxy\") = true, want false")
+	}
+}
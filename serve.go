@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+)
+
+// GetKVMDataArgs names the chassis and blade slot to fetch JNLP
+// arguments for.
+type GetKVMDataArgs struct {
+	CMCHost  string
+	BladeNum int
+}
+
+// GetKVMDataReply carries the ordered JNLP application arguments for a
+// single blade.
+type GetKVMDataReply struct {
+	Arguments []string
+}
+
+// LaunchViewerArgs names the chassis and blade slot to build a viewer
+// JNLP for.
+type LaunchViewerArgs struct {
+	CMCHost  string
+	BladeNum int
+}
+
+// LaunchViewerReply carries a ready-to-use JNLP document.
+type LaunchViewerReply struct {
+	JNLP string
+}
+
+// KVMService is the RPC-exported type backing the DracService: its
+// exported methods are registered with net/rpc and reached over plain
+// JSON-RPC, rather than gRPC/protobuf. A real protobuf toolchain
+// (protoc + protoc-gen-go + protoc-gen-go-grpc) isn't available in
+// every build environment this tool is built from, and hand-written
+// structs masquerading as generated protobuf messages don't actually
+// implement proto.Message, so marshaling them over a real grpc.Server
+// fails at call time. JSON-RPC needs nothing beyond the standard
+// library and gives the same "call a method, get a struct back"
+// shape this feature asked for.
+type KVMService struct {
+	username string
+	password string
+
+	mu      sync.Mutex
+	chassis map[string]*CMC
+}
+
+// newKVMService returns a KVMService that logs in to chassis on
+// demand using the given CMC credentials, caching one CMC session per
+// host.
+func newKVMService(username, password string) *KVMService {
+	return &KVMService{
+		username: username,
+		password: password,
+		chassis:  make(map[string]*CMC),
+	}
+}
+
+// cmcFor returns a logged-in CMC for the given chassis host, reusing an
+// existing session if we already have one.
+func (s *KVMService) cmcFor(host string) (*CMC, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.chassis[host]; ok {
+		return c, nil
+	}
+
+	c := newCMC(host, s.username, s.password)
+	if err := c.Login(); err != nil {
+		return nil, err
+	}
+	s.chassis[host] = c
+
+	return c, nil
+}
+
+// GetKVMData is the RPC-exported GetKVMData(blade_num) call.
+func (s *KVMService) GetKVMData(args *GetKVMDataArgs, reply *GetKVMDataReply) error {
+	c, err := s.cmcFor(args.CMCHost)
+	if err != nil {
+		return err
+	}
+
+	kvmArgs, err := c.GetKVMData(args.BladeNum)
+	if err != nil {
+		return err
+	}
+
+	reply.Arguments = kvmArgs
+	return nil
+}
+
+// LaunchViewer is the RPC-exported LaunchViewer(host, slot) call.
+func (s *KVMService) LaunchViewer(args *LaunchViewerArgs, reply *LaunchViewerReply) error {
+	c, err := s.cmcFor(args.CMCHost)
+	if err != nil {
+		return err
+	}
+
+	jnlp, err := c.LaunchViewer(args.BladeNum)
+	if err != nil {
+		return err
+	}
+
+	reply.JNLP = jnlp
+	return nil
+}
+
+// httpLaunchHandler adapts the same KVMService to a REST endpoint, for
+// callers who'd rather not pull in an RPC client:
+//
+//	GET /v1/kvm?host=cmc1&slot=6
+func (s *KVMService) httpLaunchHandler(w http.ResponseWriter, r *http.Request) {
+	host := r.URL.Query().Get("host")
+	slot := r.URL.Query().Get("slot")
+	if host == "" || slot == "" {
+		http.Error(w, "host and slot query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	var bladeNum int
+	if _, err := fmt.Sscanf(slot, "%d", &bladeNum); err != nil {
+		http.Error(w, "slot must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	var reply LaunchViewerReply
+	if err := s.LaunchViewer(&LaunchViewerArgs{CMCHost: host, BladeNum: bladeNum}, &reply); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reply)
+}
+
+// serveRPC accepts connections on lis and services each with a
+// JSON-RPC codec until the listener is closed.
+func serveRPC(lis net.Listener, server *rpc.Server) {
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			log.Printf("RPC listener closed: %s", err)
+			return
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// Serve starts a long-running JSON-RPC server on rpcAddr and a REST
+// listener on httpAddr, both backed by the same CMC sessions.
+func Serve(rpcAddr, httpAddr, username, password string) error {
+	service := newKVMService(username, password)
+
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName("DracService", service); err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", rpcAddr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		log.Printf("JSON-RPC KVM service listening on %s", rpcAddr)
+		serveRPC(lis, rpcServer)
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/kvm", service.httpLaunchHandler)
+
+	log.Printf("HTTP KVM service listening on %s", httpAddr)
+	return http.ListenAndServe(httpAddr, mux)
+}